@@ -0,0 +1,214 @@
+package requests
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheExpiryHeader is an internal bookkeeping header Cache adds to a
+// response before storing it, so that the absolute expiry computed at
+// store time survives a round trip through an arbitrary CacheStore.
+const cacheExpiryHeader = "X-Requests-Cache-Expiry"
+
+// CacheStore stores cached responses keyed by an opaque cache key
+// computed by Cache. A response handed to Put may have already been
+// returned from Get for the same key, in which case it replaces the
+// prior entry. Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the response previously stored under key, if any. The
+	// returned response's Body must be fresh and unread.
+	Get(key string) (*http.Response, bool)
+	// Put stores res under key until expiry. Before returning, Put must
+	// leave res.Body in a fresh, readable state (e.g. by replacing it
+	// with a buffered copy of what it read), since cacheTransport hands
+	// res back to the caller immediately after Put returns.
+	Put(key string, res *http.Response, expiry time.Time)
+}
+
+// Cache returns a Config that wraps request execution with an RFC
+// 7234-lite client-side cache backed by store: GET and HEAD responses are
+// keyed by method and URL, served from store while they're fresh per
+// Cache-Control max-age and Expires, and conditionally revalidated with
+// If-None-Match/If-Modified-Since once stale, treating a 304 as a fresh
+// hit. Responses carrying a Vary header are never cached, since a single
+// method+URL key can't safely stand in for multiple variants. This
+// complements CacheControl, which only sets the request-side header, by
+// actually enforcing the semantics.
+func Cache(store CacheStore) Config {
+	return func(rb *Builder) {
+		rb.Client(wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			return &cacheTransport{base: base, store: store}
+		}))
+	}
+}
+
+type cacheTransport struct {
+	base  http.RoundTripper
+	store CacheStore
+}
+
+func (t *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return base.RoundTrip(req)
+	}
+
+	key := req.Method + " " + req.URL.String()
+	cached, hit := t.store.Get(key)
+	if hit {
+		if cacheFresh(cached.Header) {
+			cached.Request = req
+			return cached, nil
+		}
+		addRevalidators(req, cached.Header)
+	}
+
+	res, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if hit && res.StatusCode == http.StatusNotModified {
+		res.Body.Close()
+		if expiry, cacheable := cacheExpiry(cached.Header); cacheable {
+			cached.Header.Set(cacheExpiryHeader, expiry.UTC().Format(http.TimeFormat))
+			t.store.Put(key, cached, expiry)
+		}
+		cached.Request = req
+		return cached, nil
+	}
+
+	if expiry, cacheable := cacheExpiry(res.Header); cacheable {
+		res.Header.Set(cacheExpiryHeader, expiry.UTC().Format(http.TimeFormat))
+		t.store.Put(key, res, expiry)
+	}
+	return res, nil
+}
+
+// cacheFresh reports whether a response stored with header h can still be
+// served without revalidation.
+func cacheFresh(h http.Header) bool {
+	expiresAt, err := http.ParseTime(h.Get(cacheExpiryHeader))
+	return err == nil && time.Now().Before(expiresAt)
+}
+
+// cacheExpiry computes the absolute expiry of a response with header h
+// per its Cache-Control and Expires directives. The second result is
+// false if the response must not be cached at all, and also if it
+// carries neither a max-age nor a parseable Expires to compute an
+// expiry from; such responses are not stored.
+func cacheExpiry(h http.Header) (time.Time, bool) {
+	if h.Get("Vary") != "" {
+		// A method+URL key can't distinguish between variants, so
+		// caching one would risk serving it to a request asking for
+		// another (e.g. a different Accept or Authorization).
+		return time.Time{}, false
+	}
+	for _, directive := range strings.Split(h.Get("Cache-Control"), ",") {
+		directive = strings.TrimSpace(strings.ToLower(directive))
+		if directive == "no-store" || directive == "no-cache" {
+			return time.Time{}, false
+		}
+		if rest, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if secs, err := strconv.Atoi(rest); err == nil && secs >= 0 {
+				return time.Now().Add(time.Duration(secs) * time.Second), true
+			}
+		}
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func addRevalidators(req *http.Request, cachedHeader http.Header) {
+	if etag := cachedHeader.Get("ETag"); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lm := cachedHeader.Get("Last-Modified"); lm != "" {
+		req.Header.Set("If-Modified-Since", lm)
+	}
+}
+
+// LRUCache is an in-memory CacheStore that evicts the least recently used
+// entry once it holds more than capacity entries.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache creates an LRUCache holding at most capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+type lruEntry struct {
+	key    string
+	header http.Header
+	status int
+	body   []byte
+}
+
+func (c *LRUCache) Get(key string) (*http.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	e := el.Value.(*lruEntry)
+	return &http.Response{
+		StatusCode: e.status,
+		Status:     http.StatusText(e.status),
+		Header:     e.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(e.body)),
+	}, true
+}
+
+func (c *LRUCache) Put(key string, res *http.Response, expiry time.Time) {
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := res.Header.Clone()
+	header.Set(cacheExpiryHeader, expiry.UTC().Format(http.TimeFormat))
+	entry := &lruEntry{key: key, header: header, status: res.StatusCode, body: body}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.order.MoveToFront(el)
+		return
+	}
+	el := c.order.PushFront(entry)
+	c.items[key] = el
+	if c.capacity > 0 && c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
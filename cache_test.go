@@ -0,0 +1,116 @@
+package requests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCachePreservesExistingTransport(t *testing.T) {
+	existing := &stubTransport{}
+	rb := &Builder{cl: &http.Client{Transport: existing}}
+
+	Cache(NewLRUCache(10))(rb)
+
+	ct, ok := rb.cl.Transport.(*cacheTransport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *cacheTransport", rb.cl.Transport)
+	}
+	if ct.base != existing {
+		t.Errorf("base = %v, want the Builder's existing transport", ct.base)
+	}
+}
+
+func TestCacheTransportServesFreshHitWithoutHittingServer(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("body"))
+	}))
+	defer srv.Close()
+
+	transport := &cacheTransport{store: NewLRUCache(10)}
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		res, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		res.Body.Close()
+	}
+
+	if requests != 1 {
+		t.Errorf("server requests = %d, want 1 (second should be served from cache)", requests)
+	}
+}
+
+func TestCacheTransportRevalidatesStaleEntry(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Cache-Control", "max-age=0")
+		w.Write([]byte("body"))
+	}))
+	defer srv.Close()
+
+	transport := &cacheTransport{store: NewLRUCache(10)}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip (1st): %v", err)
+	}
+	res.Body.Close()
+
+	req2, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res2, err := transport.RoundTrip(req2)
+	if err != nil {
+		t.Fatalf("RoundTrip (2nd): %v", err)
+	}
+	defer res2.Body.Close()
+
+	if requests != 2 {
+		t.Errorf("server requests = %d, want 2 (max-age=0, so stale immediately)", requests)
+	}
+	if res2.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d (304 should surface as a fresh hit)", res2.StatusCode, http.StatusOK)
+	}
+}
+
+func TestCacheTransportDoesNotMixVaryVariants(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Accept")
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Write([]byte("for-" + r.Header.Get("Accept")))
+	}))
+	defer srv.Close()
+
+	transport := &cacheTransport{store: NewLRUCache(10)}
+
+	get := func(accept string) string {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		req.Header.Set("Accept", accept)
+		res, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		defer res.Body.Close()
+		buf := make([]byte, 64)
+		n, _ := res.Body.Read(buf)
+		return string(buf[:n])
+	}
+
+	a := get("application/json")
+	b := get("text/plain")
+
+	if a == b {
+		t.Fatalf("got identical bodies %q for different Vary variants; a cached response leaked across them", a)
+	}
+}
@@ -0,0 +1,20 @@
+package requests
+
+import "net/http"
+
+// wrapClientTransport returns a client equivalent to rb's current one —
+// or a zero-value *http.Client if none has been set yet — with its
+// Transport wrapped by wrap. Configs that install a transport-level
+// feature (OAuth2, Retry, Cache, Trace, ...) use this instead of
+// constructing a bare *http.Client, so that they layer on top of
+// whatever client/transport the Builder already has (a caller's own
+// proxy or TLS config, or another such Config applied earlier) rather
+// than silently discarding it.
+func wrapClientTransport(rb *Builder, wrap func(base http.RoundTripper) http.RoundTripper) *http.Client {
+	if rb.cl == nil {
+		return &http.Client{Transport: wrap(nil)}
+	}
+	cl := *rb.cl
+	cl.Transport = wrap(cl.Transport)
+	return &cl
+}
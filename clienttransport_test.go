@@ -0,0 +1,54 @@
+package requests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// stubTransport is a no-op http.RoundTripper used where a test needs to
+// assert that a specific transport instance was preserved by pointer
+// identity. Unlike a bare func value (not comparable with ==), a pointer
+// to this type can be compared safely.
+type stubTransport struct{}
+
+func (*stubTransport) RoundTrip(*http.Request) (*http.Response, error) { return nil, nil }
+
+func TestWrapClientTransport(t *testing.T) {
+	t.Run("no existing client", func(t *testing.T) {
+		rb := &Builder{}
+		var gotBase http.RoundTripper
+		cl := wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			gotBase = base
+			return roundTripFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+		})
+		if gotBase != nil {
+			t.Errorf("base = %v, want nil", gotBase)
+		}
+		if cl.Transport == nil {
+			t.Fatal("expected a transport to be set")
+		}
+	})
+
+	t.Run("preserves existing client and transport", func(t *testing.T) {
+		existing := &stubTransport{}
+		rb := &Builder{cl: &http.Client{Transport: existing, Timeout: 7 * time.Second}}
+
+		var gotBase http.RoundTripper
+		cl := wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			gotBase = base
+			return base
+		})
+
+		if gotBase != existing {
+			t.Errorf("base = %v, want the Builder's existing transport", gotBase)
+		}
+		if cl.Timeout != 7*time.Second {
+			t.Errorf("Timeout = %v, want existing client's Timeout preserved", cl.Timeout)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package requests
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+)
+
+// ToJSONStream sets the Builder to decode the response body as JSON into
+// v, reading directly off the response body with json.NewDecoder instead
+// of buffering the whole payload first like ToJSON does. Prefer this for
+// very large responses.
+func (rb *Builder) ToJSONStream(v interface{}) *Builder {
+	return rb.Handle(func(res *http.Response) error {
+		return json.NewDecoder(res.Body).Decode(v)
+	})
+}
+
+// ToJSONDecoder sets the Builder to call f with a *json.Decoder wrapping
+// the response body, for callers that need full control over streaming
+// decode — e.g. walking a large JSON array element by element with
+// Decoder.Token and Decoder.More.
+func (rb *Builder) ToJSONDecoder(f func(*json.Decoder) error) *Builder {
+	return rb.Handle(func(res *http.Response) error {
+		return f(json.NewDecoder(res.Body))
+	})
+}
+
+// ToNDJSON sets the Builder to call f with each line of a newline
+// delimited JSON (NDJSON) response, as used by streaming APIs that emit
+// one JSON object per line. Blank lines are skipped.
+func (rb *Builder) ToNDJSON(f func(msg json.RawMessage) error) *Builder {
+	return rb.Handle(func(res *http.Response) error {
+		scanner := bufio.NewScanner(res.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			msg := make(json.RawMessage, len(line))
+			copy(msg, line)
+			if err := f(msg); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	})
+}
@@ -0,0 +1,81 @@
+package requests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestToJSONStreamDecodesBody(t *testing.T) {
+	var got struct {
+		Name string `json:"name"`
+	}
+	handler := (&Builder{}).ToJSONStream(&got).handler
+
+	res := &http.Response{Body: io.NopCloser(strings.NewReader(`{"name":"gopher"}`))}
+	if err := handler(res); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if got.Name != "gopher" {
+		t.Errorf("Name = %q, want %q", got.Name, "gopher")
+	}
+}
+
+func TestToJSONDecoderWalksArray(t *testing.T) {
+	var names []string
+	handler := (&Builder{}).ToJSONDecoder(func(dec *json.Decoder) error {
+		if _, err := dec.Token(); err != nil { // opening '['
+			return err
+		}
+		for dec.More() {
+			var s string
+			if err := dec.Decode(&s); err != nil {
+				return err
+			}
+			names = append(names, s)
+		}
+		return nil
+	}).handler
+
+	res := &http.Response{Body: io.NopCloser(strings.NewReader(`["a","b","c"]`))}
+	if err := handler(res); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; len(names) != len(want) || names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestToNDJSONSkipsBlankLines(t *testing.T) {
+	var msgs []string
+	handler := (&Builder{}).ToNDJSON(func(msg json.RawMessage) error {
+		msgs = append(msgs, string(msg))
+		return nil
+	}).handler
+
+	body := "{\"n\":1}\n\n{\"n\":2}\n"
+	res := &http.Response{Body: io.NopCloser(strings.NewReader(body))}
+	if err := handler(res); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	if len(msgs) != 2 {
+		t.Fatalf("msgs = %v, want 2 entries", msgs)
+	}
+	if msgs[0] != `{"n":1}` || msgs[1] != `{"n":2}` {
+		t.Errorf("msgs = %v, want [{\"n\":1} {\"n\":2}]", msgs)
+	}
+}
+
+func TestToNDJSONPropagatesCallbackError(t *testing.T) {
+	wantErr := io.ErrUnexpectedEOF
+	handler := (&Builder{}).ToNDJSON(func(msg json.RawMessage) error {
+		return wantErr
+	}).handler
+
+	res := &http.Response{Body: io.NopCloser(strings.NewReader("{}\n"))}
+	if err := handler(res); err != wantErr {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
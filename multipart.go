@@ -0,0 +1,91 @@
+package requests
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+)
+
+// MultipartForm accumulates fields and files to be encoded as a
+// multipart/form-data request body. Build one up with Field, File, and
+// FilePath, then attach it to a Builder with BodyMultipartForm.
+type MultipartForm struct {
+	writes []func(mw *multipart.Writer) error
+}
+
+// NewMultipartForm creates an empty MultipartForm.
+func NewMultipartForm() *MultipartForm {
+	return new(MultipartForm)
+}
+
+// Field adds a plain form field.
+func (f *MultipartForm) Field(name, value string) *MultipartForm {
+	f.writes = append(f.writes, func(mw *multipart.Writer) error {
+		return mw.WriteField(name, value)
+	})
+	return f
+}
+
+// File adds a file field whose contents are read from r.
+func (f *MultipartForm) File(fieldName, filename string, r io.Reader) *MultipartForm {
+	f.writes = append(f.writes, func(mw *multipart.Writer) error {
+		w, err := mw.CreateFormFile(fieldName, filename)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, r)
+		return err
+	})
+	return f
+}
+
+// FilePath adds a file field whose contents are read from the file at
+// path, using its base name as the filename.
+func (f *MultipartForm) FilePath(fieldName, path string) *MultipartForm {
+	f.writes = append(f.writes, func(mw *multipart.Writer) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w, err := mw.CreateFormFile(fieldName, filepath.Base(path))
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, file)
+		return err
+	})
+	return f
+}
+
+// BodyMultipart sets the Builder's request body to a multipart/form-data
+// stream, writing to mw via f as the body is read. It also sets
+// ContentType to match mw's boundary. The body is produced lazily through
+// an io.Pipe, so like BodyReader it can only be sent once.
+func (rb *Builder) BodyMultipart(f func(mw *multipart.Writer) error) *Builder {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	rb.ContentType(mw.FormDataContentType())
+	go func() {
+		err := f(mw)
+		if cerr := mw.Close(); err == nil {
+			err = cerr
+		}
+		pw.CloseWithError(err)
+	}()
+	return rb.BodyReader(pr)
+}
+
+// BodyMultipartForm sets the Builder's request body to form encoded as
+// multipart/form-data.
+func (rb *Builder) BodyMultipartForm(form *MultipartForm) *Builder {
+	return rb.BodyMultipart(func(mw *multipart.Writer) error {
+		for _, write := range form.writes {
+			if err := write(mw); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
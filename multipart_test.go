@@ -0,0 +1,109 @@
+package requests
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func contentType(rb *Builder) string {
+	for _, h := range rb.headers {
+		if h[0] == "Content-Type" {
+			return h[1]
+		}
+	}
+	return ""
+}
+
+func TestBodyMultipartFormRoundTrip(t *testing.T) {
+	form := NewMultipartForm().
+		Field("name", "gopher").
+		File("avatar", "avatar.png", strings.NewReader("fake-png-bytes"))
+
+	rb := (&Builder{}).BodyMultipartForm(form)
+
+	_, params, err := mime.ParseMediaType(contentType(rb))
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+
+	rc, err := rb.getBody()
+	if err != nil {
+		t.Fatalf("getBody: %v", err)
+	}
+	defer rc.Close()
+
+	mr := multipart.NewReader(rc, params["boundary"])
+	gotFields := map[string]string{}
+	var gotFileName, gotFileContents string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		b, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("reading part %q: %v", part.FormName(), err)
+		}
+		if part.FileName() != "" {
+			gotFileName = part.FileName()
+			gotFileContents = string(b)
+		} else {
+			gotFields[part.FormName()] = string(b)
+		}
+	}
+
+	if gotFields["name"] != "gopher" {
+		t.Errorf(`field "name" = %q, want "gopher"`, gotFields["name"])
+	}
+	if gotFileName != "avatar.png" {
+		t.Errorf("file name = %q, want %q", gotFileName, "avatar.png")
+	}
+	if gotFileContents != "fake-png-bytes" {
+		t.Errorf("file contents = %q, want %q", gotFileContents, "fake-png-bytes")
+	}
+}
+
+func TestMultipartFormFilePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hello.txt")
+	if err := os.WriteFile(path, []byte("hello from disk"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	form := NewMultipartForm().FilePath("upload", path)
+	rb := (&Builder{}).BodyMultipartForm(form)
+
+	_, params, err := mime.ParseMediaType(contentType(rb))
+	if err != nil {
+		t.Fatalf("ParseMediaType: %v", err)
+	}
+
+	rc, err := rb.getBody()
+	if err != nil {
+		t.Fatalf("getBody: %v", err)
+	}
+	defer rc.Close()
+
+	mr := multipart.NewReader(rc, params["boundary"])
+	part, err := mr.NextPart()
+	if err != nil {
+		t.Fatalf("NextPart: %v", err)
+	}
+	if part.FileName() != "hello.txt" {
+		t.Errorf("file name = %q, want %q", part.FileName(), "hello.txt")
+	}
+	b, err := io.ReadAll(part)
+	if err != nil {
+		t.Fatalf("reading part: %v", err)
+	}
+	if string(b) != "hello from disk" {
+		t.Errorf("contents = %q, want %q", b, "hello from disk")
+	}
+}
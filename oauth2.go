@@ -0,0 +1,105 @@
+package requests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// OAuth2 returns a Config that authorizes every request sent by the
+// Builder with a bearer token from ts, fetching a fresh token per request
+// as needed. A 401 response invalidates the cached token and the request
+// is retried once with a newly fetched one.
+func OAuth2(ts oauth2.TokenSource) Config {
+	cache := &cachingTokenSource{base: ts}
+	return func(rb *Builder) {
+		rb.Client(wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			return &oauth2Transport{base: base, ts: cache}
+		}))
+	}
+}
+
+// OAuth2 is a shortcut for rb.Config(OAuth2(ts)).
+func (rb *Builder) OAuth2(ts oauth2.TokenSource) *Builder {
+	return rb.Config(OAuth2(ts))
+}
+
+// ClientCredentials returns a Config that authorizes requests using the
+// OAuth2 client-credentials flow described by cfg.
+func ClientCredentials(cfg clientcredentials.Config) Config {
+	return OAuth2(cfg.TokenSource(context.Background()))
+}
+
+// cachingTokenSource wraps an oauth2.TokenSource, reusing the last token
+// until it expires or is explicitly invalidated after a 401.
+type cachingTokenSource struct {
+	mu    sync.Mutex
+	base  oauth2.TokenSource
+	token *oauth2.Token
+}
+
+func (c *cachingTokenSource) Token() (*oauth2.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token.Valid() {
+		return c.token, nil
+	}
+	tok, err := c.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	c.token = tok
+	return c.token, nil
+}
+
+func (c *cachingTokenSource) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = nil
+}
+
+type oauth2Transport struct {
+	base http.RoundTripper
+	ts   *cachingTokenSource
+}
+
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	res, err := t.authorizedRoundTrip(base, req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	if req.GetBody == nil && req.Body != nil && req.Body != http.NoBody {
+		// The body was already consumed by the first attempt and can't
+		// be rewound, so a retry would send a truncated or empty one.
+		// Hand back the original 401 instead.
+		return res, err
+	}
+	res.Body.Close()
+	t.ts.invalidate()
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("rewinding request body for OAuth2 retry: %w", err)
+		}
+		req.Body = body
+	}
+	return t.authorizedRoundTrip(base, req)
+}
+
+func (t *oauth2Transport) authorizedRoundTrip(base http.RoundTripper, req *http.Request) (*http.Response, error) {
+	tok, err := t.ts.Token()
+	if err != nil {
+		return nil, fmt.Errorf("fetching OAuth2 token: %w", err)
+	}
+	req = req.Clone(req.Context())
+	tok.SetAuthHeader(req)
+	return base.RoundTrip(req)
+}
@@ -0,0 +1,123 @@
+package requests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// tokenSourceFunc adapts a func to an oauth2.TokenSource, analogous to
+// http.HandlerFunc, for tests that need a token source producing a
+// different token on each call.
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) { return f() }
+
+func TestOAuth2PreservesExistingTransport(t *testing.T) {
+	existing := &stubTransport{}
+	rb := &Builder{cl: &http.Client{Transport: existing}}
+
+	OAuth2(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "tok"}))(rb)
+
+	ot, ok := rb.cl.Transport.(*oauth2Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *oauth2Transport", rb.cl.Transport)
+	}
+	if ot.base != existing {
+		t.Errorf("base = %v, want the Builder's existing transport", ot.base)
+	}
+}
+
+func TestOAuth2RoundTrip(t *testing.T) {
+	var gotAuth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "tok", TokenType: "Bearer"})
+	transport := &oauth2Transport{ts: &cachingTokenSource{base: ts}}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	res.Body.Close()
+
+	if want := "Bearer tok"; len(gotAuth) != 1 || gotAuth[0] != want {
+		t.Errorf("Authorization = %v, want [%q]", gotAuth, want)
+	}
+}
+
+func TestOAuth2RetriesBodylessGETOn401(t *testing.T) {
+	var gotAuth []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = append(gotAuth, r.Header.Get("Authorization"))
+		if len(gotAuth) == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	calls := 0
+	ts := tokenSourceFunc(func() (*oauth2.Token, error) {
+		calls++
+		return &oauth2.Token{AccessToken: fmt.Sprintf("tok%d", calls), TokenType: "Bearer"}, nil
+	})
+	transport := &oauth2Transport{ts: &cachingTokenSource{base: ts}}
+
+	// A bodyless GET has no GetBody, same as any request with no body,
+	// but that must not be mistaken for an unrewindable body.
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if want := []string{"Bearer tok1", "Bearer tok2"}; len(gotAuth) != 2 || gotAuth[0] != want[0] || gotAuth[1] != want[1] {
+		t.Errorf("Authorization headers = %v, want %v", gotAuth, want)
+	}
+}
+
+func TestOAuth2UnauthorizedBodyNotRewindable(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "tok", TokenType: "Bearer"})
+	transport := &oauth2Transport{ts: &cachingTokenSource{base: ts}}
+
+	// A body with no GetBody (as set by BodyReader/BodyWriter) can't be
+	// rewound after the first attempt consumes it.
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+	req.GetBody = nil
+
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	defer res.Body.Close()
+
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry with a dead body)", attempts)
+	}
+	if res.StatusCode != http.StatusUnauthorized {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,160 @@
+package requests
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures Retry's backoff schedule and retry decisions.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent,
+	// including the first attempt. Values less than 1 are treated as 1.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff delay before Jitter is
+	// applied. A delay taken from a Retry-After header is not capped.
+	MaxDelay time.Duration
+	// Jitter randomizes each backoff delay by up to this fraction, e.g.
+	// 0.2 for +/-20%. A delay taken from a Retry-After header is used
+	// as is.
+	Jitter float64
+	// ShouldRetry decides whether a given outcome should be retried. err
+	// is non-nil only for network-level failures; res is nil in that
+	// case.
+	ShouldRetry func(res *http.Response, err error) bool
+}
+
+// RetryIdempotent returns a RetryPolicy that retries on network errors
+// and 5xx responses, up to 3 attempts with exponential backoff between
+// 100ms and 5s. Its ShouldRetry does not look at the request method, so
+// non-idempotent requests such as POST are retried as well.
+func RetryIdempotent() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+		ShouldRetry: func(res *http.Response, err error) bool {
+			if err != nil {
+				return true
+			}
+			return res.StatusCode >= 500
+		},
+	}
+}
+
+// RetryTransient returns a RetryPolicy that retries any method on network
+// errors, 429, and 5xx responses, up to maxAttempts attempts.
+func RetryTransient(maxAttempts int) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+		ShouldRetry: func(res *http.Response, err error) bool {
+			if err != nil {
+				return true
+			}
+			return res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+		},
+	}
+}
+
+// errBodyNotRewindable marks a retry as abandoned because the request
+// body can't be resent, e.g. one set with BodyReader or BodyWriter.
+var errBodyNotRewindable = errors.New("request body is not rewindable, cannot retry")
+
+// Retry returns a Config that resends a request per policy whenever its
+// outcome is retryable, rewinding the request body via its BodyGetter
+// between attempts and honoring Retry-After on 429/503 responses.
+// Requests whose body can't be rewound are sent at most once.
+func Retry(policy RetryPolicy) Config {
+	return func(rb *Builder) {
+		rb.Client(wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			return &retryTransport{base: base, policy: policy}
+		}))
+	}
+}
+
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	attempts := t.policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var res *http.Response
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if req.GetBody == nil {
+				if req.Body != nil && req.Body != http.NoBody {
+					return res, errBodyNotRewindable
+				}
+			} else {
+				body, rerr := req.GetBody()
+				if rerr != nil {
+					return nil, rerr
+				}
+				req.Body = body
+			}
+		}
+
+		res, err = base.RoundTrip(req)
+		if attempt == attempts || !t.policy.ShouldRetry(res, err) {
+			return res, err
+		}
+
+		delay := t.delay(attempt, res)
+		if res != nil {
+			res.Body.Close()
+		}
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	return res, err
+}
+
+func (t *retryTransport) delay(attempt int, res *http.Response) time.Duration {
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+	d := t.policy.BaseDelay << (attempt - 1)
+	if t.policy.MaxDelay > 0 && d > t.policy.MaxDelay {
+		d = t.policy.MaxDelay
+	}
+	if t.policy.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 - t.policy.Jitter + 2*t.policy.Jitter*rand.Float64()))
+	}
+	return d
+}
+
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return time.Until(at), true
+	}
+	return 0, false
+}
@@ -0,0 +1,154 @@
+package requests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryPreservesExistingTransport(t *testing.T) {
+	existing := &stubTransport{}
+	rb := &Builder{cl: &http.Client{Transport: existing}}
+
+	Retry(RetryIdempotent())(rb)
+
+	rt, ok := rb.cl.Transport.(*retryTransport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *retryTransport", rb.cl.Transport)
+	}
+	if rt.base != existing {
+		t.Errorf("base = %v, want the Builder's existing transport", rt.base)
+	}
+}
+
+func TestRetryTransportRetriesOn5xx(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transport := &retryTransport{policy: RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		ShouldRetry: func(res *http.Response, err error) bool {
+			return err != nil || res.StatusCode >= 500
+		},
+	}}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	res.Body.Close()
+
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("StatusCode = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+}
+
+func TestRetryTransportHonorsRetryAfter(t *testing.T) {
+	var times []time.Time
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		if len(times) < 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	transport := &retryTransport{policy: RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Hour, // would dominate the delay if Retry-After weren't honored
+		ShouldRetry: func(res *http.Response, err error) bool {
+			return err != nil || res.StatusCode == http.StatusTooManyRequests
+		},
+	}}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	res.Body.Close()
+
+	if len(times) != 2 {
+		t.Fatalf("attempts = %d, want 2", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap < 900*time.Millisecond || gap > 3*time.Second {
+		t.Errorf("gap between attempts = %v, want ~1s (from Retry-After)", gap)
+	}
+}
+
+func TestRetryTransportBodyNotRewindable(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	transport := &retryTransport{policy: RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		ShouldRetry: func(res *http.Response, err error) bool {
+			return err != nil || res.StatusCode >= 500
+		},
+	}}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("payload"))
+	req.GetBody = nil
+
+	res, err := transport.RoundTrip(req)
+	if err != errBodyNotRewindable {
+		t.Fatalf("err = %v, want errBodyNotRewindable", err)
+	}
+	if res != nil {
+		res.Body.Close()
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (no retry with a dead body)", attempts)
+	}
+}
+
+func TestRetryTransportContextCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	transport := &retryTransport{policy: RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		ShouldRetry: func(res *http.Response, err error) bool {
+			return err != nil || res.StatusCode >= 500
+		},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := transport.RoundTrip(req)
+	if err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
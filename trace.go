@@ -0,0 +1,130 @@
+package requests
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/http/httputil"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHooks holds optional callbacks invoked around each request sent by
+// a Builder configured with Trace. Any of them may be left nil.
+type TraceHooks struct {
+	// BeforeRequest is called immediately before the request is sent.
+	BeforeRequest func(req *http.Request)
+	// AfterResponse is called once a response (or error) is received. err
+	// is non-nil only for network-level failures.
+	AfterResponse func(res *http.Response, err error)
+	// DumpBody records whether TraceHTTPDump was asked to include request
+	// and response bodies. Trace does not read it, and changing it on
+	// hooks returned by TraceHTTPDump does not change what they dump.
+	DumpBody bool
+}
+
+// Trace returns a Config that invokes hooks' callbacks around every
+// request sent by the Builder and, when the request's context carries a
+// recording OpenTelemetry span, records the round trip and its
+// DNS/connect/TLS timings (observed via net/http/httptrace) as span
+// events. Without a recording span, no timings are reported.
+func Trace(hooks TraceHooks) Config {
+	return func(rb *Builder) {
+		rb.Client(wrapClientTransport(rb, func(base http.RoundTripper) http.RoundTripper {
+			return &traceTransport{base: base, hooks: hooks}
+		}))
+	}
+}
+
+type traceTransport struct {
+	base  http.RoundTripper
+	hooks TraceHooks
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.hooks.BeforeRequest != nil {
+		t.hooks.BeforeRequest(req)
+	}
+
+	span := trace.SpanFromContext(req.Context())
+	req = req.Clone(httptrace.WithClientTrace(req.Context(), spanClientTrace(span)))
+
+	start := time.Now()
+	res, err := base.RoundTrip(req)
+	dur := time.Since(start)
+
+	if span.IsRecording() {
+		attrs := []attribute.KeyValue{
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+			attribute.Int64("http.duration_ms", dur.Milliseconds()),
+		}
+		if err != nil {
+			span.RecordError(err)
+		} else {
+			attrs = append(attrs, attribute.Int("http.status_code", res.StatusCode))
+		}
+		span.AddEvent("http.roundtrip", trace.WithAttributes(attrs...))
+	}
+
+	if t.hooks.AfterResponse != nil {
+		t.hooks.AfterResponse(res, err)
+	}
+	return res, err
+}
+
+// spanClientTrace builds an httptrace.ClientTrace that mirrors each
+// connection-lifecycle callback as a span event, when span is recording.
+func spanClientTrace(span trace.Span) *httptrace.ClientTrace {
+	event := func(name string) {
+		if span.IsRecording() {
+			span.AddEvent(name)
+		}
+	}
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { event("dns.start") },
+		DNSDone:              func(httptrace.DNSDoneInfo) { event("dns.done") },
+		ConnectStart:         func(string, string) { event("connect.start") },
+		ConnectDone:          func(string, string, error) { event("connect.done") },
+		TLSHandshakeStart:    func() { event("tls.start") },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { event("tls.done") },
+		GotFirstResponseByte: func() { event("response.first_byte") },
+	}
+}
+
+// TraceHTTPDump returns TraceHooks that dump each request and response to
+// w using net/http/httputil, for quick ad hoc debugging. Pass dumpBody to
+// additionally include request and response bodies in the dump.
+func TraceHTTPDump(w io.Writer, dumpBody bool) TraceHooks {
+	return TraceHooks{
+		DumpBody: dumpBody,
+		BeforeRequest: func(req *http.Request) {
+			b, err := httputil.DumpRequestOut(req, dumpBody)
+			if err != nil {
+				fmt.Fprintf(w, "requests: dumping request: %v\n", err)
+				return
+			}
+			w.Write(b)
+		},
+		AfterResponse: func(res *http.Response, err error) {
+			if err != nil {
+				fmt.Fprintf(w, "requests: round trip error: %v\n", err)
+				return
+			}
+			b, derr := httputil.DumpResponse(res, dumpBody)
+			if derr != nil {
+				fmt.Fprintf(w, "requests: dumping response: %v\n", derr)
+				return
+			}
+			w.Write(b)
+		},
+	}
+}
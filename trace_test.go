@@ -0,0 +1,52 @@
+package requests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracePreservesExistingTransport(t *testing.T) {
+	existing := &stubTransport{}
+	rb := &Builder{cl: &http.Client{Transport: existing}}
+
+	Trace(TraceHooks{})(rb)
+
+	tt, ok := rb.cl.Transport.(*traceTransport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *traceTransport", rb.cl.Transport)
+	}
+	if tt.base != existing {
+		t.Errorf("base = %v, want the Builder's existing transport", tt.base)
+	}
+}
+
+func TestTraceHTTPDumpRespectsDumpBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("response-body-marker"))
+	}))
+	defer srv.Close()
+
+	for _, dumpBody := range []bool{true, false} {
+		var buf bytes.Buffer
+		hooks := TraceHTTPDump(&buf, dumpBody)
+		if hooks.DumpBody != dumpBody {
+			t.Errorf("DumpBody = %v, want %v", hooks.DumpBody, dumpBody)
+		}
+
+		transport := &traceTransport{hooks: hooks}
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader("request-body-marker"))
+		res, err := transport.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		res.Body.Close()
+
+		gotBody := strings.Contains(buf.String(), "response-body-marker")
+		if gotBody != dumpBody {
+			t.Errorf("dumpBody=%v: dump contains response body = %v, want %v", dumpBody, gotBody, dumpBody)
+		}
+	}
+}